@@ -0,0 +1,139 @@
+package beater
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/elastic/beats/libbeat/paths"
+
+	"github.com/radoondas/jmxproxybeat/config"
+)
+
+// configFiles collects repeated -c flags, in the order given on the
+// command line.
+type configFiles []string
+
+func (c *configFiles) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *configFiles) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
+// configOverrides collects repeated -E name=value flags.
+type configOverrides []string
+
+func (c *configOverrides) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *configOverrides) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
+var (
+	cfgFiles   configFiles
+	overrides  configOverrides
+	configTest = flag.Bool("configtest", false, "Test the merged configuration and exit")
+)
+
+func init() {
+	flag.Var(&cfgFiles, "c", "Configuration file, can be given multiple times; later files win")
+	flag.Var(&overrides, "E", "Configuration overwrite, e.g. -E jmxproxybeat.period=30s")
+}
+
+// LoadConfig reads every -c file (resolved against path.config, defaulting
+// to jmxproxybeat.yml when -c was not given), deep-merges them in order so
+// later files take precedence, applies -E overrides on top following the
+// same precedence, and unpacks the result into a config.Config.
+func LoadConfig() (*config.Config, error) {
+	files := []string(cfgFiles)
+	if len(files) == 0 {
+		files = []string{"jmxproxybeat.yml"}
+	}
+
+	merged := map[interface{}]interface{}{}
+	for _, f := range files {
+		resolved := paths.Resolve(paths.Config, f)
+
+		raw, err := ioutil.ReadFile(resolved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %v", resolved, err)
+		}
+
+		var layer map[interface{}]interface{}
+		if err := yaml.Unmarshal(raw, &layer); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %v", resolved, err)
+		}
+
+		mergeConfig(merged, layer)
+	}
+
+	for _, o := range overrides {
+		if err := applyOverride(merged, o); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-marshal merged configuration: %v", err)
+	}
+
+	cfg := &config.Config{}
+	if err := yaml.Unmarshal(out, cfg); err != nil {
+		return nil, fmt.Errorf("failed to unpack merged configuration: %v", err)
+	}
+
+	return cfg, nil
+}
+
+// RunConfigTest reports whether -configtest was passed on the command
+// line.
+func RunConfigTest() bool {
+	return *configTest
+}
+
+// mergeConfig deep-merges src into dst, with src winning on conflicts.
+func mergeConfig(dst, src map[interface{}]interface{}) {
+	for k, v := range src {
+		if srcMap, ok := v.(map[interface{}]interface{}); ok {
+			if dstMap, ok := dst[k].(map[interface{}]interface{}); ok {
+				mergeConfig(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// applyOverride sets a dotted "name=value" pair (e.g.
+// "jmxproxybeat.period=30s") into dst, creating intermediate maps as
+// needed.
+func applyOverride(dst map[interface{}]interface{}, kv string) error {
+	parts := strings.SplitN(kv, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid -E override %q, expected name=value", kv)
+	}
+
+	keys := strings.Split(parts[0], ".")
+	node := dst
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := node[key].(map[interface{}]interface{})
+		if !ok {
+			next = map[interface{}]interface{}{}
+			node[key] = next
+		}
+		node = next
+	}
+	node[keys[len(keys)-1]] = parts[1]
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package beater
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeConfigOverwritesScalars(t *testing.T) {
+	dst := map[interface{}]interface{}{
+		"period": "10s",
+		"hosts":  "base",
+	}
+	src := map[interface{}]interface{}{
+		"period": "30s",
+	}
+
+	mergeConfig(dst, src)
+
+	if dst["period"] != "30s" {
+		t.Errorf("expected period to be overwritten to 30s, got %v", dst["period"])
+	}
+	if dst["hosts"] != "base" {
+		t.Errorf("expected hosts to be left alone, got %v", dst["hosts"])
+	}
+}
+
+func TestMergeConfigDeepMergesNestedMaps(t *testing.T) {
+	dst := map[interface{}]interface{}{
+		"jmxproxybeat": map[interface{}]interface{}{
+			"period": "10s",
+			"hosts":  "base",
+		},
+	}
+	src := map[interface{}]interface{}{
+		"jmxproxybeat": map[interface{}]interface{}{
+			"period": "30s",
+		},
+	}
+
+	mergeConfig(dst, src)
+
+	want := map[interface{}]interface{}{
+		"jmxproxybeat": map[interface{}]interface{}{
+			"period": "30s",
+			"hosts":  "base",
+		},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("mergeConfig() = %#v, want %#v", dst, want)
+	}
+}
+
+func TestMergeConfigReplacesMapWithScalar(t *testing.T) {
+	dst := map[interface{}]interface{}{
+		"jmxproxybeat": map[interface{}]interface{}{"period": "10s"},
+	}
+	src := map[interface{}]interface{}{
+		"jmxproxybeat": "disabled",
+	}
+
+	mergeConfig(dst, src)
+
+	if dst["jmxproxybeat"] != "disabled" {
+		t.Errorf("expected scalar from src to win, got %#v", dst["jmxproxybeat"])
+	}
+}
+
+func TestApplyOverrideSetsNestedKey(t *testing.T) {
+	dst := map[interface{}]interface{}{}
+
+	if err := applyOverride(dst, "jmxproxybeat.period=30s"); err != nil {
+		t.Fatalf("applyOverride() error = %v", err)
+	}
+
+	jmx, ok := dst["jmxproxybeat"].(map[interface{}]interface{})
+	if !ok {
+		t.Fatalf("expected jmxproxybeat to be a map, got %#v", dst["jmxproxybeat"])
+	}
+	if jmx["period"] != "30s" {
+		t.Errorf("expected period = 30s, got %v", jmx["period"])
+	}
+}
+
+func TestApplyOverrideRejectsMissingEquals(t *testing.T) {
+	if err := applyOverride(map[interface{}]interface{}{}, "jmxproxybeat.period"); err == nil {
+		t.Error("expected an error for an override without '=', got nil")
+	}
+}
+
+func TestApplyOverrideValueContainingEquals(t *testing.T) {
+	dst := map[interface{}]interface{}{}
+
+	if err := applyOverride(dst, "jmxproxybeat.url=jndi/rmi://host:1=2"); err != nil {
+		t.Fatalf("applyOverride() error = %v", err)
+	}
+
+	jmx := dst["jmxproxybeat"].(map[interface{}]interface{})
+	if jmx["url"] != "jndi/rmi://host:1=2" {
+		t.Errorf("expected value to keep embedded '=', got %v", jmx["url"])
+	}
+}
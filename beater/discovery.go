@@ -0,0 +1,98 @@
+package beater
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+
+	"github.com/radoondas/jmxproxybeat/config"
+)
+
+const jmxRemotePortFlag = "-Dcom.sun.management.jmxremote.port="
+
+// DiscoverHostfsTargets scans the host's /proc, bind-mounted under
+// path.hostfs (e.g. /hostfs when jmxproxybeat runs in a container), for
+// local Java processes started with
+// -Dcom.sun.management.jmxremote.port=<port> and synthesizes a
+// config.Host for each one found. It is a no-op when path.hostfs was not
+// configured, and logs a config warning when it was configured but the
+// mount isn't actually there.
+//
+// hostAddr is the address the synthesized URLs use to reach those JVMs.
+// It defaults to "localhost" when empty, which only works if jmxproxybeat
+// itself runs with host networking; set
+// jmxproxybeat.hostfs_address otherwise.
+func DiscoverHostfsTargets(hostAddr string) ([]config.Host, error) {
+	if paths.Paths.Hostfs == "" {
+		return nil, nil
+	}
+
+	if hostAddr == "" {
+		hostAddr = "localhost"
+		logp.Warn("jmxproxybeat.hostfs_address is not set, defaulting to %q; this only reaches JVMs discovered via path.hostfs if jmxproxybeat runs with host networking", hostAddr)
+	}
+
+	procDir := paths.Resolve(paths.Hostfs, "proc")
+	entries, err := ioutil.ReadDir(procDir)
+	if err != nil {
+		logp.Warn("path.hostfs is set to %s but %s could not be read, is the host /proc bind-mounted? %v",
+			paths.Paths.Hostfs, procDir, err)
+		return nil, nil
+	}
+
+	var hosts []config.Host
+	for _, entry := range entries {
+		pid := entry.Name()
+		if !isPIDDir(pid) {
+			continue
+		}
+
+		cmdline, err := ioutil.ReadFile(filepath.Join(procDir, pid, "cmdline"))
+		if err != nil {
+			continue
+		}
+
+		port, ok := parseJMXPort(cmdline)
+		if !ok {
+			continue
+		}
+
+		hosts = append(hosts, config.Host{
+			Name: "hostfs-pid-" + pid,
+			URL:  "service:jmx:rmi:///jndi/rmi://" + hostAddr + ":" + port + "/jmxrmi",
+		})
+	}
+
+	return hosts, nil
+}
+
+// isPIDDir reports whether name looks like a /proc pid directory, i.e. it
+// is made up entirely of digits.
+func isPIDDir(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseJMXPort scans a /proc/<pid>/cmdline payload (NUL-separated
+// arguments) for a -Dcom.sun.management.jmxremote.port=<port> flag and
+// returns its value, if present.
+func parseJMXPort(cmdline []byte) (string, bool) {
+	for _, arg := range bytes.Split(cmdline, []byte{0}) {
+		value := string(arg)
+		if strings.HasPrefix(value, jmxRemotePortFlag) {
+			return strings.TrimPrefix(value, jmxRemotePortFlag), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+package beater
+
+import "testing"
+
+func TestIsPIDDir(t *testing.T) {
+	cases := map[string]bool{
+		"1":      true,
+		"1234":   true,
+		"self":   false,
+		"net":    false,
+		"":       false,
+		"123abc": false,
+		"-1":     false,
+	}
+
+	for name, want := range cases {
+		if got := isPIDDir(name); got != want {
+			t.Errorf("isPIDDir(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestParseJMXPortFindsFlag(t *testing.T) {
+	cmdline := []byte("java\x00-Xmx512m\x00-Dcom.sun.management.jmxremote.port=9010\x00-jar\x00app.jar\x00")
+
+	port, ok := parseJMXPort(cmdline)
+	if !ok {
+		t.Fatal("expected to find a jmxremote.port flag, got none")
+	}
+	if port != "9010" {
+		t.Errorf("port = %q, want %q", port, "9010")
+	}
+}
+
+func TestParseJMXPortNoFlag(t *testing.T) {
+	cmdline := []byte("java\x00-jar\x00app.jar\x00")
+
+	if _, ok := parseJMXPort(cmdline); ok {
+		t.Error("expected no jmxremote.port flag to be found")
+	}
+}
+
+func TestParseJMXPortEmptyCmdline(t *testing.T) {
+	if _, ok := parseJMXPort(nil); ok {
+		t.Error("expected no jmxremote.port flag in an empty cmdline")
+	}
+}
+
+func TestParseJMXPortNoTrailingNUL(t *testing.T) {
+	// Some kernels/processes produce a cmdline with no terminating NUL on
+	// the final argument.
+	cmdline := []byte("java\x00-Dcom.sun.management.jmxremote.port=9010")
+
+	port, ok := parseJMXPort(cmdline)
+	if !ok || port != "9010" {
+		t.Errorf("parseJMXPort() = (%q, %v), want (\"9010\", true)", port, ok)
+	}
+}
+
+func TestParseJMXPortFirstMatchWins(t *testing.T) {
+	cmdline := []byte("java\x00-Dcom.sun.management.jmxremote.port=9010\x00-Dcom.sun.management.jmxremote.port=9011\x00")
+
+	port, ok := parseJMXPort(cmdline)
+	if !ok || port != "9010" {
+		t.Errorf("parseJMXPort() = (%q, %v), want (\"9010\", true)", port, ok)
+	}
+}
@@ -0,0 +1,116 @@
+// Package beater implements the jmxproxybeat Beater interface used by
+// libbeat to drive the poll loop.
+package beater
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/publisher"
+
+	"github.com/radoondas/jmxproxybeat/config"
+)
+
+// Jmxproxybeat publishes one event per configured JMX proxy target on a
+// fixed interval. Each event carries only the timestamp, the event type and
+// the target's name; no metrics are fetched from the targets.
+type Jmxproxybeat struct {
+	beatConfig config.Config
+	done       chan struct{}
+	period     time.Duration
+	client     publisher.Client
+}
+
+// New returns a new, unconfigured Jmxproxybeat.
+func New() *Jmxproxybeat {
+	return &Jmxproxybeat{
+		done: make(chan struct{}),
+	}
+}
+
+// Config reads jmxproxybeat's configuration file(s), applies any -E
+// overrides, and initializes logging.
+func (bt *Jmxproxybeat) Config(b *beat.Beat) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		if RunConfigTest() {
+			fmt.Fprintf(os.Stderr, "Config OK: false\n%v\n", err)
+			os.Exit(1)
+		}
+		return err
+	}
+	bt.beatConfig = *cfg
+
+	// -configtest validates the parsed config and exits; it must not have
+	// side effects like creating log files or scanning /hostfs/proc, so
+	// it's checked before initLogging/DiscoverHostfsTargets run.
+	if RunConfigTest() {
+		fmt.Println("Config OK")
+		os.Exit(0)
+	}
+
+	if err := initLogging(&bt.beatConfig); err != nil {
+		return err
+	}
+
+	bt.period, err = time.ParseDuration(bt.beatConfig.Jmxproxybeat.Period)
+	if err != nil {
+		logp.Warn("invalid jmxproxybeat.period %q, defaulting to 1s: %v", bt.beatConfig.Jmxproxybeat.Period, err)
+		bt.period = 1 * time.Second
+	}
+	if bt.period <= 0 {
+		logp.Warn("jmxproxybeat.period must be positive, got %s, defaulting to 1s", bt.period)
+		bt.period = 1 * time.Second
+	}
+
+	discovered, err := DiscoverHostfsTargets(bt.beatConfig.Jmxproxybeat.HostfsAddr)
+	if err != nil {
+		return err
+	}
+	bt.beatConfig.Jmxproxybeat.Hosts = append(bt.beatConfig.Jmxproxybeat.Hosts, discovered...)
+
+	return nil
+}
+
+// Setup connects jmxproxybeat to the publisher pipeline.
+func (bt *Jmxproxybeat) Setup(b *beat.Beat) error {
+	bt.client = b.Publisher.Connect()
+	return nil
+}
+
+// Run starts the poll loop and blocks until Stop is called.
+func (bt *Jmxproxybeat) Run(b *beat.Beat) error {
+	logp.Info("jmxproxybeat is running! Hit CTRL-C to stop it.")
+
+	ticker := time.NewTicker(bt.period)
+	for {
+		select {
+		case <-bt.done:
+			return nil
+		case <-ticker.C:
+		}
+
+		for _, host := range bt.beatConfig.Jmxproxybeat.Hosts {
+			event := common.MapStr{
+				"@timestamp": common.Time(time.Now()),
+				"type":       "jmxproxybeat",
+				"host":       host.Name,
+			}
+			bt.client.PublishEvent(event)
+		}
+	}
+}
+
+// Cleanup is a no-op for jmxproxybeat.
+func (bt *Jmxproxybeat) Cleanup(b *beat.Beat) error {
+	return nil
+}
+
+// Stop signals the poll loop to exit.
+func (bt *Jmxproxybeat) Stop() {
+	close(bt.done)
+}
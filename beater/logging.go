@@ -0,0 +1,63 @@
+package beater
+
+import (
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+
+	"github.com/radoondas/jmxproxybeat/config"
+)
+
+const (
+	defaultRotateEveryBytes = uint(10 * 1024 * 1024)
+	defaultKeepFiles        = 7
+)
+
+// initLogging translates cfg.Logging into a real logp.Logging and
+// initializes logp from it. When the configuration does not already
+// point logging at an explicit output, it defaults to a size- and
+// count-bounded rotating file appender under path.logs (e.g.
+// /var/log/jmxproxybeat when installed via DEB/RPM) so operators always
+// have a predictable place to look for jmxproxybeat's own logs.
+func initLogging(cfg *config.Config) error {
+	logging := logp.Logging{
+		ToFiles:   cfg.Logging.ToFiles,
+		ToSyslog:  cfg.Logging.ToSyslog,
+		Level:     cfg.Logging.Level,
+		Selectors: cfg.Logging.Selectors,
+	}
+
+	if cfg.Logging.Files != nil {
+		logging.Files = &logp.FileRotator{
+			Path:             cfg.Logging.Files.Path,
+			Name:             cfg.Logging.Files.Name,
+			RotateEveryBytes: cfg.Logging.Files.RotateEveryBytes,
+			KeepFiles:        cfg.Logging.Files.KeepFiles,
+		}
+	}
+
+	if logging.Files == nil {
+		rotateEveryBytes := defaultRotateEveryBytes
+		keepFiles := defaultKeepFiles
+
+		logging.Files = &logp.FileRotator{
+			Path:             paths.Resolve(paths.Logs, ""),
+			Name:             "jmxproxybeat",
+			RotateEveryBytes: &rotateEveryBytes,
+			KeepFiles:        &keepFiles,
+		}
+	}
+
+	if logging.ToFiles == nil {
+		toFiles := true
+		logging.ToFiles = &toFiles
+	}
+
+	// Per-level filtering: default to "info" unless the config already
+	// set a level, and pass Selectors through untouched so operators can
+	// still opt into debug selectors.
+	if logging.Level == "" {
+		logging.Level = "info"
+	}
+
+	return logp.Init("jmxproxybeat", &logging)
+}
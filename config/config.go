@@ -0,0 +1,56 @@
+// Package config defines the configuration schema that jmxproxybeat reads
+// from its YAML configuration file.
+package config
+
+// Config is the top level jmxproxybeat configuration file.
+type Config struct {
+	Jmxproxybeat Jmxproxybeat `yaml:"jmxproxybeat"`
+	Logging      Logging      `yaml:"logging"`
+}
+
+// Logging mirrors the subset of libbeat's logp.Logging that
+// beater.LoadConfig understands. logp.Logging is meant to be unmarshaled
+// by go-ucfg using `config:"..."` tags (snake_case keys like to_files),
+// but LoadConfig round-trips the merged configuration through
+// gopkg.in/yaml.v2, which only honors `yaml:"..."` tags; a copy of
+// logp.Logging embedded directly would silently fail to bind any of
+// those keys. beater.initLogging translates a Logging into the real
+// logp.Logging before calling logp.Init.
+type Logging struct {
+	ToFiles   *bool        `yaml:"to_files"`
+	ToSyslog  *bool        `yaml:"to_syslog"`
+	Level     string       `yaml:"level"`
+	Selectors []string     `yaml:"selectors"`
+	Files     *FileRotator `yaml:"files"`
+}
+
+// FileRotator mirrors logp.FileRotator; see Logging for why this exists
+// as a separate, yaml-tagged copy.
+type FileRotator struct {
+	Path             string `yaml:"path"`
+	Name             string `yaml:"name"`
+	RotateEveryBytes *uint  `yaml:"rotateeverybytes"`
+	KeepFiles        *int   `yaml:"keepfiles"`
+}
+
+// Jmxproxybeat holds the settings that control how JMX proxy targets are
+// polled.
+type Jmxproxybeat struct {
+	Period string `yaml:"period"`
+	Hosts  []Host `yaml:"hosts"`
+
+	// HostfsAddr is the address used to reach JVMs discovered via
+	// path.hostfs (see beater.DiscoverHostfsTargets). It defaults to
+	// "localhost", which is only reachable when jmxproxybeat itself runs
+	// with host networking; set it explicitly (e.g. to the host's
+	// overlay/bridge IP) otherwise.
+	HostfsAddr string `yaml:"hostfs_address"`
+}
+
+// Host describes a single JMX endpoint to poll.
+type Host struct {
+	Name     string `yaml:"name"`
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
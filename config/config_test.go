@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TestLoggingYAMLKeys documents (and pins down) the exact snake_case keys
+// that beater.LoadConfig's yaml.v2 round-trip honors for the logging
+// section, since they don't match logp.Logging's own `config:"..."` tags.
+func TestLoggingYAMLKeys(t *testing.T) {
+	raw := []byte(`
+to_files: false
+to_syslog: true
+level: debug
+selectors:
+  - jmx
+  - publish
+files:
+  path: /var/log/jmxproxybeat
+  name: jmxproxybeat
+  rotateeverybytes: 10485760
+  keepfiles: 7
+`)
+
+	var logging Logging
+	if err := yaml.Unmarshal(raw, &logging); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	if logging.ToFiles == nil || *logging.ToFiles != false {
+		t.Errorf("ToFiles = %v, want false", logging.ToFiles)
+	}
+	if logging.ToSyslog == nil || *logging.ToSyslog != true {
+		t.Errorf("ToSyslog = %v, want true", logging.ToSyslog)
+	}
+	if logging.Level != "debug" {
+		t.Errorf("Level = %q, want %q", logging.Level, "debug")
+	}
+	if len(logging.Selectors) != 2 || logging.Selectors[0] != "jmx" || logging.Selectors[1] != "publish" {
+		t.Errorf("Selectors = %v, want [jmx publish]", logging.Selectors)
+	}
+
+	if logging.Files == nil {
+		t.Fatal("Files = nil, want a FileRotator")
+	}
+	if logging.Files.Path != "/var/log/jmxproxybeat" {
+		t.Errorf("Files.Path = %q, want %q", logging.Files.Path, "/var/log/jmxproxybeat")
+	}
+	if logging.Files.Name != "jmxproxybeat" {
+		t.Errorf("Files.Name = %q, want %q", logging.Files.Name, "jmxproxybeat")
+	}
+	if logging.Files.RotateEveryBytes == nil || *logging.Files.RotateEveryBytes != 10485760 {
+		t.Errorf("Files.RotateEveryBytes = %v, want 10485760", logging.Files.RotateEveryBytes)
+	}
+	if logging.Files.KeepFiles == nil || *logging.Files.KeepFiles != 7 {
+		t.Errorf("Files.KeepFiles = %v, want 7", logging.Files.KeepFiles)
+	}
+}
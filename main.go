@@ -1,14 +1,37 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 
 	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/paths"
 
 	"github.com/radoondas/jmxproxybeat/beater"
 )
 
+var printPaths = flag.Bool("paths", false, "Print the resolved location of every file jmxproxybeat reads or writes, then exit")
+
 func main() {
+	flag.Parse()
+
+	if *printPaths {
+		if err := paths.InitPaths(&paths.Path{}); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to resolve paths: %v\n", err)
+			os.Exit(1)
+		}
+
+		pretty, err := paths.PrettyPaths()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format paths: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(pretty)
+		return
+	}
+
 	err := beat.Run("jmxproxybeat", "", beater.New())
 	if err != nil {
 		os.Exit(1)
@@ -21,6 +21,7 @@
 package paths
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -32,6 +33,7 @@ var (
 	configPath = flag.String("path.config", "", "Configuration path")
 	dataPath   = flag.String("path.data", "", "Data path")
 	logsPath = flag.String("path.logs", "", "Logs path")
+	hostfsPath = flag.String("path.hostfs", "", "Hostfs path (for containerized deployments)")
 )
 
 type Path struct {
@@ -39,10 +41,11 @@ type Path struct {
 	Config string
 	Data   string
 	Logs   string
+	Hostfs string
 }
 
 // FileType is an enumeration type representing the file types.
-// Currently existing file types are: Home, Config, Data
+// Currently existing file types are: Home, Config, Data, Logs, Hostfs
 type FileType string
 
 const (
@@ -50,6 +53,7 @@ const (
 	Config FileType = "config"
 	Data   FileType = "data"
 	Logs FileType = "logs"
+	Hostfs FileType = "hostfs"
 )
 
 // Paths is the Path singleton on which the top level functions from this
@@ -93,6 +97,7 @@ func (paths *Path) initPaths(cfg *Path) error {
 	paths.Config = cfg.Config
 	paths.Data = cfg.Data
 	paths.Logs = cfg.Logs
+	paths.Hostfs = cfg.Hostfs
 
 	// overwrite paths from CLI flags
 	if homePath != nil && len(*homePath) > 0 {
@@ -107,6 +112,9 @@ func (paths *Path) initPaths(cfg *Path) error {
 	if logsPath != nil && len(*logsPath) > 0 {
 		paths.Logs = *logsPath
 	}
+	if hostfsPath != nil && len(*hostfsPath) > 0 {
+		paths.Hostfs = *hostfsPath
+	}
 
 	// default for the home path is the binary location
 	if len(paths.Home) == 0 {
@@ -154,6 +162,8 @@ func (paths *Path) Resolve(fileType FileType, path string) string {
 		return filepath.Join(paths.Data, path)
 	case Logs:
 		return filepath.Join(paths.Logs, path)
+	case Hostfs:
+		return filepath.Join(paths.Hostfs, path)
 	default:
 		panic(fmt.Sprintf("Unknown file type: %s", fileType))
 	}
@@ -168,6 +178,85 @@ func Resolve(fileType FileType, path string) string {
 
 // String returns a textual representation
 func (paths *Path) String() string {
-	return fmt.Sprintf("Home path: [%s] Config path: [%s] Data path: [%s] Logs path: [%s]",
-		paths.Home, paths.Config, paths.Data, paths.Logs)
+	return fmt.Sprintf("Home path: [%s] Config path: [%s] Data path: [%s] Logs path: [%s] Hostfs path: [%s]",
+		paths.Home, paths.Config, paths.Data, paths.Logs, paths.Hostfs)
+}
+
+// LocationEnum identifies a named, well-known resource file that a beat
+// needs to read or write, independent of which base folder it happens to
+// live under.
+type LocationEnum string
+
+const (
+	RegistryFile LocationEnum = "registry-file"
+	TemplateFile LocationEnum = "template-file"
+	CACertFile   LocationEnum = "ca-cert-file"
+	KeystoreFile LocationEnum = "keystore-file"
+	PIDFile      LocationEnum = "pid-file"
+)
+
+// location is the default definition of a LocationEnum: a relative path
+// resolved against one of the base FileTypes.
+type location struct {
+	fileType FileType
+	path     string
+}
+
+// locations holds the default definition for every known LocationEnum.
+var locations = map[LocationEnum]location{
+	RegistryFile: {Data, "registry"},
+	TemplateFile: {Config, "jmxproxybeat.template.json"},
+	CACertFile:   {Config, "ca.crt"},
+	KeystoreFile: {Data, "jmxproxybeat.keystore"},
+	PIDFile:      {Data, "jmxproxybeat.pid"},
+}
+
+// locationOverrides holds any per-key overrides installed with
+// SetLocation, taking precedence over the default in locations.
+var locationOverrides = map[LocationEnum]string{}
+
+// SetLocation overrides the resolved path for key, e.g. to let an
+// operator point the keystore at a path that isn't under path.data.
+func SetLocation(key LocationEnum, override string) {
+	locationOverrides[key] = override
+}
+
+// Location resolves a named LocationEnum to its absolute path, honoring
+// any override installed with SetLocation. It panics if key is not a
+// known LocationEnum, the same way Resolve panics on an unknown FileType.
+func Location(key LocationEnum) string {
+	loc, ok := locations[key]
+	if !ok {
+		panic(fmt.Sprintf("Unknown location: %s", key))
+	}
+
+	if override, ok := locationOverrides[key]; ok {
+		return Paths.Resolve(loc.fileType, override)
+	}
+
+	return Paths.Resolve(loc.fileType, loc.path)
+}
+
+// PrettyPaths returns a stable, pretty-printed JSON dump of every path a
+// beat will read or write: the base folders (home, config, data, logs,
+// hostfs) plus every named Location.
+func PrettyPaths() (string, error) {
+	out := map[string]string{
+		"home":   Paths.Home,
+		"config": Paths.Config,
+		"data":   Paths.Data,
+		"logs":   Paths.Logs,
+		"hostfs": Paths.Hostfs,
+	}
+
+	for key := range locations {
+		out[string(key)] = Location(key)
+	}
+
+	buf, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
 }